@@ -0,0 +1,167 @@
+// Package decoder turns a stream of audio samples containing a Morse tone
+// back into text, using a Goertzel tone detector and an adaptive dot-length
+// estimator instead of a fixed WPM assumption.
+package decoder
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+
+	"github.com/pavelanni/morse-go/morse"
+)
+
+const (
+	sampleRate = 44100
+	blockMs    = 10 // ~10 ms analysis blocks
+	blockSize  = sampleRate * blockMs / 1000
+)
+
+// Decoder detects a tone at freq and decodes it into runes using
+// morse.ReverseCodeMap. It tracks a running noise floor and an EMA of the
+// shortest confirmed on-run to use as the current dot unit, so it adapts to
+// the sender's speed instead of assuming a fixed WPM.
+type Decoder struct {
+	freq int
+
+	noiseFloor float64
+	dotUnitMs  float64 // EMA of the shortest confirmed on-run, in ms
+
+	toneOn  bool
+	runMs   int
+	pattern string
+}
+
+// NewDecoder creates a Decoder tuned to detect tone at freq Hz. seedTiming
+// is used only to seed the initial dot-unit estimate; the decoder adapts
+// away from it as real on-runs are observed.
+func NewDecoder(freq int, seedTiming morse.TimingConfig) *Decoder {
+	dotDuration, _, _, _, _ := seedTiming.Durations()
+	return &Decoder{
+		freq:      freq,
+		dotUnitMs: float64(dotDuration),
+	}
+}
+
+// Decode reads 16-bit little-endian PCM samples from r and emits decoded
+// runes on the returned channel as character and word gaps resolve them.
+// The channel is closed once r is exhausted.
+func (d *Decoder) Decode(r io.Reader) <-chan rune {
+	out := make(chan rune)
+	go func() {
+		defer close(out)
+		block := make([]int16, blockSize)
+		raw := make([]byte, blockSize*2)
+		for {
+			n, err := io.ReadFull(r, raw)
+			if n == 0 {
+				break
+			}
+			samples := n / 2
+			for i := 0; i < samples; i++ {
+				block[i] = int16(binary.LittleEndian.Uint16(raw[i*2:]))
+			}
+			d.processBlock(block[:samples], out)
+			if err != nil {
+				break
+			}
+		}
+		d.flush(out)
+	}()
+	return out
+}
+
+// processBlock runs the Goertzel detector over one analysis block and
+// folds the on/off decision into the run-length state machine.
+func (d *Decoder) processBlock(block []int16, out chan<- rune) {
+	power := d.goertzel(block)
+
+	// Track a slow running noise floor from blocks we currently believe are
+	// silence only; folding tone power into it too made the floor climb
+	// toward the tone's own power during a long element (e.g. a dash) and
+	// made the detector lose lock partway through it.
+	if !d.toneOn {
+		d.noiseFloor = d.noiseFloor*0.95 + power*0.05
+	}
+	threshold := d.noiseFloor*4 + 1e-6
+	toneOn := power > threshold
+
+	if toneOn == d.toneOn {
+		d.runMs += blockMs
+		return
+	}
+
+	// The run just ended; classify it before switching state.
+	d.classifyRun(d.toneOn, d.runMs, out)
+	d.toneOn = toneOn
+	d.runMs = blockMs
+}
+
+// goertzel computes the single-bin Goertzel power of block at d.freq.
+func (d *Decoder) goertzel(block []int16) float64 {
+	n := len(block)
+	if n == 0 {
+		return 0
+	}
+	k := math.Round(float64(n) * float64(d.freq) / sampleRate)
+	omega := 2 * math.Pi * k / float64(n)
+	coeff := 2 * math.Cos(omega)
+
+	var s1, s2 float64
+	for _, sample := range block {
+		x := float64(sample) / 32768
+		s := x + coeff*s1 - s2
+		s2 = s1
+		s1 = s
+	}
+	return s1*s1 + s2*s2 - coeff*s1*s2
+}
+
+// classifyRun folds a completed on/off run into the current character
+// pattern, emitting a rune on character gaps and a space on word gaps.
+func (d *Decoder) classifyRun(wasOn bool, runMs int, out chan<- rune) {
+	unit := d.dotUnitMs
+
+	if wasOn {
+		if runMs <= int(2*unit) && d.runIsConfirmedDot(runMs) {
+			d.dotUnitMs = d.dotUnitMs*0.8 + float64(runMs)*0.2
+			d.pattern += "."
+		} else {
+			d.pattern += "-"
+		}
+		return
+	}
+
+	switch {
+	case float64(runMs) > 5*unit:
+		d.emitChar(out)
+		out <- ' '
+	case float64(runMs) > 2*unit:
+		d.emitChar(out)
+	}
+}
+
+// runIsConfirmedDot treats any on-run no longer than 1.5 times the current
+// estimate as a dot candidate, letting the EMA track toward the sender's
+// real speed.
+func (d *Decoder) runIsConfirmedDot(runMs int) bool {
+	return float64(runMs) <= d.dotUnitMs*1.5
+}
+
+// emitChar resolves the accumulated dot/dash pattern to a rune and sends
+// it, resetting the pattern buffer.
+func (d *Decoder) emitChar(out chan<- rune) {
+	if d.pattern == "" {
+		return
+	}
+	if r, ok := morse.ReverseCodeMap[d.pattern]; ok {
+		out <- r
+	}
+	d.pattern = ""
+}
+
+// flush classifies any trailing run once the input stream ends.
+func (d *Decoder) flush(out chan<- rune) {
+	d.classifyRun(d.toneOn, d.runMs, out)
+	d.emitChar(out)
+}
@@ -0,0 +1,62 @@
+package decoder
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/pavelanni/morse-go/morse"
+)
+
+func TestGoertzelPeaksAtTargetFrequency(t *testing.T) {
+	d := &Decoder{freq: 600}
+
+	block := make([]int16, blockSize)
+	for i := range block {
+		block[i] = int16(math.Sin(2*math.Pi*600*float64(i)/sampleRate) * 16000)
+	}
+	onFreq := d.goertzel(block)
+
+	offTone := &Decoder{freq: 600}
+	for i := range block {
+		block[i] = int16(math.Sin(2*math.Pi*1200*float64(i)/sampleRate) * 16000)
+	}
+	offFreq := offTone.goertzel(block)
+
+	if onFreq <= offFreq {
+		t.Errorf("goertzel power at target freq (%.2f) should exceed power at a different freq (%.2f)", onFreq, offFreq)
+	}
+}
+
+func TestGoertzelSilenceIsNearZero(t *testing.T) {
+	d := &Decoder{freq: 600}
+	block := make([]int16, blockSize)
+	if power := d.goertzel(block); power > 1 {
+		t.Errorf("goertzel power on silence = %.4f, want near 0", power)
+	}
+}
+
+// TestDecodeRoundTrip generates real Morse audio for a message with
+// MorseReader and feeds it through Decoder.Decode, exercising the full
+// Goertzel/run-length state machine end to end rather than goertzel alone.
+func TestDecodeRoundTrip(t *testing.T) {
+	const freq = 600
+	timing := morse.NewTiming(20)
+	table, _ := morse.Table("itu")
+	envelope := morse.EnvelopeConfig{RiseTimeMs: 5, FallTimeMs: 5, Shape: morse.RaisedCosine}
+
+	reader := morse.NewMorseReaderString("SOS", table, timing, freq, envelope)
+	var pcm strings.Builder
+	if _, err := reader.WriteTo(&pcm); err != nil {
+		t.Fatalf("generating Morse audio: %v", err)
+	}
+
+	var got strings.Builder
+	for r := range NewDecoder(freq, timing).Decode(strings.NewReader(pcm.String())) {
+		got.WriteRune(r)
+	}
+
+	if want := "SOS"; strings.TrimSpace(got.String()) != want {
+		t.Errorf("Decode round-trip = %q, want %q", got.String(), want)
+	}
+}
@@ -0,0 +1,176 @@
+//go:build portaudio
+
+// Package keyer provides a live, real-time Morse keyer built on top of a
+// full-duplex PortAudio stream: it turns keyboard/paddle input into Morse
+// timing and plays the corresponding tone back immediately.
+package keyer
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/gordonklaus/portaudio"
+	"github.com/pavelanni/morse-go/morse"
+)
+
+const sampleRate = 44100
+
+// ElementFunc is called once per emitted dot or dash, with "." or "-".
+type ElementFunc func(element string)
+
+// CharFunc is called once a full character (letter/digit) has been keyed,
+// resolved once the inter-character gap elapses with no new KeyDown.
+type CharFunc func(char rune)
+
+// Keyer streams a keyed tone to the default audio output in real time. It
+// times each KeyDown/KeyUp press itself to classify dots and dashes, and
+// watches the gap after KeyUp to decide when a character is finished.
+type Keyer struct {
+	freq   int
+	timing morse.TimingConfig
+
+	onElement ElementFunc
+	onChar    CharFunc
+
+	stream *portaudio.Stream
+	mu     sync.Mutex
+	phase  float64
+	toneOn bool
+
+	downAt    time.Time
+	pattern   string
+	charTimer *time.Timer
+}
+
+// NewKeyer creates a Keyer that keys a sine wave at freq Hz, timed
+// according to timing. Either callback may be nil.
+func NewKeyer(freq int, timing morse.TimingConfig, onElement ElementFunc, onChar CharFunc) *Keyer {
+	return &Keyer{
+		freq:      freq,
+		timing:    timing,
+		onElement: onElement,
+		onChar:    onChar,
+	}
+}
+
+// Start opens the full-duplex PortAudio stream and begins serving audio
+// callbacks. The keyer stays silent until KeyDown/KeyUp drive it.
+func (k *Keyer) Start() error {
+	if err := portaudio.Initialize(); err != nil {
+		return fmt.Errorf("keyer: initialize portaudio: %w", err)
+	}
+
+	stream, err := portaudio.OpenDefaultStream(1, 1, float64(sampleRate), 0, k.fillBuffer)
+	if err != nil {
+		portaudio.Terminate()
+		return fmt.Errorf("keyer: open stream: %w", err)
+	}
+	if err := stream.Start(); err != nil {
+		stream.Close()
+		portaudio.Terminate()
+		return fmt.Errorf("keyer: start stream: %w", err)
+	}
+
+	k.stream = stream
+	return nil
+}
+
+// Stop closes the audio stream and releases PortAudio.
+func (k *Keyer) Stop() error {
+	k.mu.Lock()
+	if k.charTimer != nil {
+		k.charTimer.Stop()
+		k.charTimer = nil
+	}
+	k.mu.Unlock()
+
+	if k.stream == nil {
+		return nil
+	}
+	err := k.stream.Close()
+	portaudio.Terminate()
+	k.stream = nil
+	return err
+}
+
+// KeyDown begins a tone (straight key or paddle closure) and cancels any
+// pending character resolution, since a new element belongs to the
+// character in progress.
+func (k *Keyer) KeyDown() {
+	k.mu.Lock()
+	if k.charTimer != nil {
+		k.charTimer.Stop()
+		k.charTimer = nil
+	}
+	k.downAt = time.Now()
+	k.toneOn = true
+	k.mu.Unlock()
+}
+
+// KeyUp ends the current tone, classifies the element that was just keyed
+// from how long KeyDown was held relative to the dot duration, and reports
+// it via onElement. It then arms a timer for the configured inter-character
+// gap: if KeyDown doesn't arrive before it fires, the accumulated pattern
+// is resolved to a character and reported via onChar.
+func (k *Keyer) KeyUp() {
+	k.mu.Lock()
+	elapsed := time.Since(k.downAt)
+	k.toneOn = false
+
+	dotDuration, _, _, charGap, _ := k.timing.Durations()
+	element := "."
+	if elapsed > time.Duration(dotDuration)*2*time.Millisecond {
+		element = "-"
+	}
+	k.pattern += element
+
+	if k.charTimer != nil {
+		k.charTimer.Stop()
+	}
+	k.charTimer = time.AfterFunc(time.Duration(charGap)*time.Millisecond, k.resolveChar)
+	onElement := k.onElement
+	k.mu.Unlock()
+
+	if onElement != nil {
+		onElement(element)
+	}
+}
+
+// resolveChar fires once the inter-character gap has elapsed with no new
+// KeyDown, decoding the accumulated pattern and reporting it via onChar.
+func (k *Keyer) resolveChar() {
+	k.mu.Lock()
+	pattern := k.pattern
+	k.pattern = ""
+	k.charTimer = nil
+	onChar := k.onChar
+	k.mu.Unlock()
+
+	if pattern == "" || onChar == nil {
+		return
+	}
+	if r, ok := morse.ReverseCodeMap[pattern]; ok {
+		onChar(r)
+	}
+}
+
+// fillBuffer is the PortAudio duplex callback: it ignores the input buffer
+// and writes a continuous sine tone while toneOn is set and silence
+// otherwise.
+func (k *Keyer) fillBuffer(in, out []float32) {
+	k.mu.Lock()
+	toneOn := k.toneOn
+	k.mu.Unlock()
+
+	step := 2 * math.Pi * float64(k.freq) / float64(sampleRate)
+	for i := range out {
+		if toneOn {
+			out[i] = float32(math.Sin(k.phase))
+			k.phase += step
+		} else {
+			out[i] = 0
+		}
+	}
+}
@@ -0,0 +1,7 @@
+//go:build !portaudio
+
+// Package keyer provides a live, real-time Morse keyer. The real
+// implementation (keyer.go) needs PortAudio and is only built with
+// `-tags portaudio`; without that tag this file keeps the package
+// buildable but exports nothing.
+package keyer
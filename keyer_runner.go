@@ -0,0 +1,9 @@
+package main
+
+import "github.com/pavelanni/morse-go/morse"
+
+// keyerRunner is populated by init() in main_keyer.go, built only with
+// -tags portaudio (see keyer/keyer.go). A nil keyerRunner means -key is
+// unavailable, which is reported as a runtime error instead of failing
+// the default build for everyone without PortAudio installed.
+var keyerRunner func(freq int, timing morse.TimingConfig) error
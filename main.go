@@ -1,221 +1,180 @@
 package main
 
 import (
-	"bytes"
 	"encoding/binary"
 	"flag"
 	"fmt"
-	"math"
-	"strings"
-	"time"
+	"io"
+	"os"
 
-	"github.com/hajimehoshi/ebiten/v2/audio"
-	"github.com/hajimehoshi/ebiten/v2/audio/wav"
+	"github.com/pavelanni/morse-go/decoder"
+	"github.com/pavelanni/morse-go/morse"
+	"github.com/pavelanni/morse-go/output"
 )
 
 const (
-	sampleRate = 44100
-)
+	sampleRate = morse.SampleRate
 
-// Morse code table
-var morseCodeMap = map[rune]string{
-	'A': ".-", 'B': "-...", 'C': "-.-.", 'D': "-..", 'E': ".", 'F': "..-.",
-	'G': "--.", 'H': "....", 'I': "..", 'J': ".---", 'K': "-.-", 'L': ".-..",
-	'M': "--", 'N': "-.", 'O': "---", 'P': ".--.", 'Q': "--.-", 'R': ".-.",
-	'S': "...", 'T': "-", 'U': "..-", 'V': "...-", 'W': ".--", 'X': "-..-",
-	'Y': "-.--", 'Z': "--..",
-	'0': "-----", '1': ".----", '2': "..---", '3': "...--", '4': "....-",
-	'5': ".....", '6': "-....", '7': "--...", '8': "---..", '9': "----.",
-	'/': "-..-.", '?': "..--..", '.': ".-.-.-", ',': "--..--",
-}
+	// streamChunkBytes is the read buffer size used to pull samples from
+	// the MorseReader; it must stay even so every read ends on a whole
+	// sample boundary (see MorseReader.Read).
+	streamChunkBytes = 4096
+)
 
-// Pre-generated audio samples for each character
-type morseAudio struct {
-	dotSamples  []int16
-	dashSamples []int16
-	elementGap  []int16
-	charGap     []int16
-	wordGap     []int16
-	charSamples map[rune][]int16
-}
+func main() {
+	// Parse command line arguments
+	text := flag.String("text", "SOS", "Text to convert to Morse code")
+	wpm := flag.Int("wpm", 20, "Character speed in words per minute")
+	effWPM := flag.Int("eff-wpm", 0, "Farnsworth effective speed in WPM (0 disables Farnsworth spacing)")
+	freq := flag.Int("freq", 600, "Tone frequency in Hz")
+	riseMs := flag.Float64("rise-ms", 5, "Envelope rise time in milliseconds")
+	fallMs := flag.Float64("fall-ms", 5, "Envelope fall time in milliseconds")
+	outputKind := flag.String("output", "wav", "Output sink: wav|mp3|ebiten|portaudio|-")
+	outFile := flag.String("out-file", "", "Output file path for file sinks (- or empty means stdout)")
+	tableName := flag.String("table", "itu", "Code table: itu|cyrillic|greek|wabun")
+	kochLevel := flag.Int("koch-level", 0, "Koch training lesson level (0 disables, otherwise only characters introduced up to this level are enabled)")
+	decodeMode := flag.Bool("decode", false, "Decode Morse audio from stdin (16-bit PCM at -freq) instead of encoding -text")
+	keyMode := flag.Bool("key", false, "Key Morse live from '.'/'-' typed on stdin (requires building with -tags portaudio)")
+	flag.Parse()
 
-// calculateMorseTiming calculates timing from WPM using PARIS standard
-func calculateMorseTiming(wpm int) (dotDuration, dashDuration, elementGap, charGap, wordGap int) {
-	if wpm <= 0 {
-		wpm = 20 // Default to 20 WPM
+	timing := morse.NewTiming(*wpm)
+	if *effWPM > 0 {
+		timing = morse.NewFarnsworthTiming(*wpm, *effWPM)
 	}
 
-	// 1 time unit duration in milliseconds
-	timeUnit := 60000 / (wpm * 50) // 60 seconds * 1000 ms / (wpm * 50 units per PARIS)
-
-	dotDuration = timeUnit
-	dashDuration = timeUnit * 3
-	elementGap = timeUnit
-	charGap = timeUnit * 3
-	wordGap = timeUnit * 7
+	if *decodeMode {
+		if err := runDecode(*freq, timing); err != nil {
+			panic(err)
+		}
+		return
+	}
+	if *keyMode {
+		if keyerRunner == nil {
+			panic("-key requires building with -tags portaudio")
+		}
+		if err := keyerRunner(*freq, timing); err != nil {
+			panic(err)
+		}
+		return
+	}
 
-	return
-}
+	envelope := morse.EnvelopeConfig{RiseTimeMs: *riseMs, FallTimeMs: *fallMs, Shape: morse.RaisedCosine}
 
-// newMorseAudio creates a new morseAudio instance with pre-generated samples
-func newMorseAudio(wpm int, freq int) *morseAudio {
-	dotDuration, dashDuration, elementGap, charGap, wordGap := calculateMorseTiming(wpm)
-
-	// Convert durations from milliseconds to samples
-	dotSamples := int(float64(dotDuration) * sampleRate / 1000)
-	dashSamples := int(float64(dashDuration) * sampleRate / 1000)
-	elementGapSamples := int(float64(elementGap) * sampleRate / 1000)
-	charGapSamples := int(float64(charGap) * sampleRate / 1000)
-	wordGapSamples := int(float64(wordGap) * sampleRate / 1000)
-
-	// Generate basic elements
-	dot := make([]int16, dotSamples)
-	dash := make([]int16, dashSamples)
-	elementGapAudio := make([]int16, elementGapSamples)
-	charGapAudio := make([]int16, charGapSamples)
-	wordGapAudio := make([]int16, wordGapSamples)
-
-	// Generate tone for dot and dash
-	for i := 0; i < dotSamples; i++ {
-		dot[i] = int16(math.Sin(2*math.Pi*float64(freq)*float64(i)/float64(sampleRate)) * 32767)
+	table, ok := morse.Table(*tableName)
+	if !ok {
+		panic(fmt.Sprintf("unknown -table %q", *tableName))
 	}
-	for i := 0; i < dashSamples; i++ {
-		dash[i] = int16(math.Sin(2*math.Pi*float64(freq)*float64(i)/float64(sampleRate)) * 32767)
+	if *kochLevel > 0 {
+		table = &morse.CodeTable{Name: "koch", Letters: morse.KochCodeMap(*kochLevel), Prosigns: table.Prosigns}
 	}
 
-	// Pre-generate samples for each character
-	charSamples := make(map[rune][]int16)
-	for char, morse := range morseCodeMap {
-		var samples []int16
-		for i, element := range morse {
-			if element == '.' {
-				samples = append(samples, dot...)
-			} else if element == '-' {
-				samples = append(samples, dash...)
-			}
-			if i < len(morse)-1 {
-				samples = append(samples, elementGapAudio...)
-			}
-		}
-		charSamples[char] = samples
+	sink, err := newSink(*outputKind, *outFile)
+	if err != nil {
+		panic(err)
 	}
 
-	return &morseAudio{
-		dotSamples:  dot,
-		dashSamples: dash,
-		elementGap:  elementGapAudio,
-		charGap:     charGapAudio,
-		wordGap:     wordGapAudio,
-		charSamples: charSamples,
+	fmt.Fprintf(os.Stderr, "Playing '%s' in Morse code at %d WPM, %d Hz (output: %s)\n", *text, *wpm, *freq, *outputKind)
+	if err := streamTo(sink, *text, table, timing, *freq, envelope); err != nil {
+		panic(err)
+	}
+	if err := sink.Close(); err != nil {
+		panic(err)
 	}
 }
 
-// generateMorseAudio generates audio for a given text in Morse code
-func generateMorseAudio(text string, wpm int, freq int) ([]int16, int) {
-	morse := newMorseAudio(wpm, freq)
+// runDecode reads 16-bit little-endian PCM samples from stdin and prints
+// the text decoder.Decode recovers from them.
+func runDecode(freq int, timing morse.TimingConfig) error {
+	for r := range decoder.NewDecoder(freq, timing).Decode(os.Stdin) {
+		fmt.Printf("%c", r)
+	}
+	fmt.Println()
+	return nil
+}
 
-	// Calculate total duration needed
-	totalSamples := 0
-	for i, char := range strings.ToUpper(text) {
-		if char == ' ' {
-			totalSamples += len(morse.wordGap)
-			continue
-		}
+// streamTo renders text through a MorseReader and forwards it to sink, so
+// generating a long message never allocates the whole sample sequence at
+// once. Sinks that also implement io.Writer (every file/buffer-backed one
+// except PortAudioSink) get the samples via reader.WriteTo, its
+// allocation-free path straight from []int16 to bytes; this avoids
+// bouncing through an int16 conversion on the way in (bytesToSamples) and
+// another back out (WriteSamples' binary.Write) for no reason. Sinks that
+// need real per-sample work, like PortAudioSink converting to float32,
+// fall back to reading fixed-size chunks and calling WriteSamples.
+func streamTo(sink output.Sink, text string, table *morse.CodeTable, timing morse.TimingConfig, freq int, envelope morse.EnvelopeConfig) error {
+	reader := morse.NewMorseReaderString(text, table, timing, freq, envelope)
+
+	if w, ok := sink.(io.Writer); ok {
+		_, err := reader.WriteTo(w)
+		return err
+	}
 
-		if samples, ok := morse.charSamples[char]; ok {
-			totalSamples += len(samples)
-			if i < len(text)-1 && text[i+1] != ' ' {
-				totalSamples += len(morse.charGap)
+	buf := make([]byte, streamChunkBytes)
+	for {
+		n, err := reader.Read(buf)
+		if n > 0 {
+			if werr := sink.WriteSamples(bytesToSamples(buf[:n])); werr != nil {
+				return werr
 			}
 		}
-	}
-
-	// Generate the audio samples
-	samples := make([]int16, totalSamples)
-	currentSample := 0
-
-	for i, char := range strings.ToUpper(text) {
-		if char == ' ' {
-			copy(samples[currentSample:], morse.wordGap)
-			currentSample += len(morse.wordGap)
-			continue
+		if err == io.EOF {
+			return nil
 		}
-
-		if charSamples, ok := morse.charSamples[char]; ok {
-			copy(samples[currentSample:], charSamples)
-			currentSample += len(charSamples)
-
-			// Add character gap if not the last character
-			if i < len(text)-1 && text[i+1] != ' ' {
-				copy(samples[currentSample:], morse.charGap)
-				currentSample += len(morse.charGap)
-			}
+		if err != nil {
+			return err
 		}
 	}
-
-	return samples, totalSamples
 }
 
-func main() {
-	// Parse command line arguments
-	text := flag.String("text", "SOS", "Text to convert to Morse code")
-	wpm := flag.Int("wpm", 20, "Speed in words per minute")
-	freq := flag.Int("freq", 600, "Tone frequency in Hz")
-	flag.Parse()
-
-	acontext := audio.NewContext(sampleRate)
-
-	// Generate Morse code audio
-	samples, totalSamples := generateMorseAudio(*text, *wpm, *freq)
-
-	// Create a buffer and write WAV data
-	buf := &bytes.Buffer{}
-	writeWavHeader(buf, totalSamples*2, sampleRate)
-
-	// Write PCM data
-	for _, sample := range samples {
-		binary.Write(buf, binary.LittleEndian, sample)
+// bytesToSamples reinterprets a little-endian byte chunk as int16 samples.
+// buf is always an even length: streamChunkBytes is even and Read only
+// ever returns an odd count when asked for an odd-length buffer.
+func bytesToSamples(buf []byte) []int16 {
+	samples := make([]int16, len(buf)/2)
+	for i := range samples {
+		samples[i] = int16(binary.LittleEndian.Uint16(buf[i*2:]))
 	}
-
-	// Create a reader from the buffer
-	reader := bytes.NewReader(buf.Bytes())
-
-	// Play the sound
-	p, err := wav.DecodeWithSampleRate(sampleRate, reader)
-	if err != nil {
-		panic(err)
-	}
-
-	player, err := acontext.NewPlayer(p)
-	if err != nil {
-		panic(err)
-	}
-
-	fmt.Printf("Playing '%s' in Morse code at %d WPM, %d Hz\n", *text, *wpm, *freq)
-	player.Play()
-
-	// Calculate total duration and wait for playback to complete
-	totalDuration := time.Duration(float64(totalSamples) / float64(sampleRate) * float64(time.Second))
-	time.Sleep(totalDuration)
+	return samples
 }
 
-func writeWavHeader(w *bytes.Buffer, dataSize int, sampleRate int) {
-	// RIFF header
-	w.Write([]byte("RIFF"))
-	binary.Write(w, binary.LittleEndian, uint32(36+dataSize))
-	w.Write([]byte("WAVE"))
-
-	// fmt chunk
-	w.Write([]byte("fmt "))
-	binary.Write(w, binary.LittleEndian, uint32(16)) // fmt chunk size
-	binary.Write(w, binary.LittleEndian, uint16(1))  // audio format (1 for PCM)
-	binary.Write(w, binary.LittleEndian, uint16(1))  // number of channels
-	binary.Write(w, binary.LittleEndian, uint32(sampleRate))
-	binary.Write(w, binary.LittleEndian, uint32(sampleRate*2)) // byte rate
-	binary.Write(w, binary.LittleEndian, uint16(2))            // block align
-	binary.Write(w, binary.LittleEndian, uint16(16))           // bits per sample
-
-	// data chunk
-	w.Write([]byte("data"))
-	binary.Write(w, binary.LittleEndian, uint32(dataSize))
+// newSink builds the output.Sink selected by -output, opening outFile (or
+// stdout) for the file-based sinks. ebiten/portaudio/mp3 depend on system
+// audio libraries and are only wired in when the binary is built with the
+// matching build tag (see main_*.go); without it, selecting them is a
+// runtime error instead of a build failure for everyone else.
+func newSink(kind, outFile string) (output.Sink, error) {
+	switch kind {
+	case "wav", "mp3", "-":
+		w := os.Stdout
+		if outFile != "" && outFile != "-" {
+			f, err := os.Create(outFile)
+			if err != nil {
+				return nil, fmt.Errorf("open %s: %w", outFile, err)
+			}
+			w = f
+		}
+		switch kind {
+		case "wav":
+			return output.NewWAVSink(w, sampleRate)
+		case "mp3":
+			if mp3SinkFactory == nil {
+				return nil, fmt.Errorf("output %q requires building with -tags mp3", kind)
+			}
+			return mp3SinkFactory(w, sampleRate), nil
+		default:
+			return output.NewPCMSink(w), nil
+		}
+	case "ebiten":
+		if ebitenSinkFactory == nil {
+			return nil, fmt.Errorf("output %q requires building with -tags ebiten", kind)
+		}
+		return ebitenSinkFactory(sampleRate), nil
+	case "portaudio":
+		if portaudioSinkFactory == nil {
+			return nil, fmt.Errorf("output %q requires building with -tags portaudio", kind)
+		}
+		return portaudioSinkFactory(sampleRate)
+	default:
+		return nil, fmt.Errorf("unknown output kind %q", kind)
+	}
 }
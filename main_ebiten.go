@@ -0,0 +1,14 @@
+//go:build ebiten
+
+package main
+
+import (
+	"github.com/hajimehoshi/ebiten/v2/audio"
+	"github.com/pavelanni/morse-go/output"
+)
+
+func init() {
+	ebitenSinkFactory = func(sampleRate int) output.Sink {
+		return output.NewEbitenSink(audio.NewContext(sampleRate), sampleRate)
+	}
+}
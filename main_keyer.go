@@ -0,0 +1,57 @@
+//go:build portaudio
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/pavelanni/morse-go/keyer"
+	"github.com/pavelanni/morse-go/morse"
+)
+
+func init() {
+	keyerRunner = runKeyer
+}
+
+// runKeyer reads lines of '.' and '-' from stdin and keys each one through
+// a live Keyer, holding it down for the configured dot/dash duration. That
+// drives the Keyer's own KeyDown/KeyUp timing and character resolution for
+// real, the same way a paddle or straight key would, instead of only
+// inside keyer's own tests.
+func runKeyer(freq int, timing morse.TimingConfig) error {
+	k := keyer.NewKeyer(freq, timing,
+		func(element string) { fmt.Print(element) },
+		func(char rune) { fmt.Printf(" [%c]\n", char) },
+	)
+	if err := k.Start(); err != nil {
+		return err
+	}
+	defer k.Stop()
+
+	dotMs, dashMs, _, _, _ := timing.Durations()
+	fmt.Println("Type '.' and '-', Enter to flush a line, Ctrl-D to quit")
+
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		for _, r := range scanner.Text() {
+			switch r {
+			case '.':
+				keyElement(k, time.Duration(dotMs)*time.Millisecond)
+			case '-':
+				keyElement(k, time.Duration(dashMs)*time.Millisecond)
+			}
+		}
+	}
+	return scanner.Err()
+}
+
+// keyElement holds the key down for d, reproducing the press/release pair
+// a real paddle or straight key would generate for one element.
+func keyElement(k *keyer.Keyer, d time.Duration) {
+	k.KeyDown()
+	time.Sleep(d)
+	k.KeyUp()
+}
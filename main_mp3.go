@@ -0,0 +1,15 @@
+//go:build mp3
+
+package main
+
+import (
+	"io"
+
+	"github.com/pavelanni/morse-go/output"
+)
+
+func init() {
+	mp3SinkFactory = func(w io.Writer, sampleRate int) output.Sink {
+		return output.NewMP3Sink(w, sampleRate)
+	}
+}
@@ -0,0 +1,11 @@
+//go:build portaudio
+
+package main
+
+import "github.com/pavelanni/morse-go/output"
+
+func init() {
+	portaudioSinkFactory = func(sampleRate int) (output.Sink, error) {
+		return output.NewPortAudioSink(sampleRate)
+	}
+}
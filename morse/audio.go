@@ -0,0 +1,145 @@
+package morse
+
+import "math"
+
+// SampleRate is the sample rate, in Hz, used throughout the package.
+const SampleRate = 44100
+
+// AudioSet holds the pre-generated per-element audio samples (dot, dash,
+// and the three gap lengths) plus per-letter and per-prosign sample
+// sequences built from a CodeTable, so callers don't regenerate a sine
+// wave for every occurrence of a letter.
+type AudioSet struct {
+	DotSamples     []int16
+	DashSamples    []int16
+	ElementGap     []int16
+	CharGap        []int16
+	WordGap        []int16
+	LetterSamples  map[rune][]int16
+	ProsignSamples map[string][]int16
+}
+
+// NewAudioSet pre-generates the dot/dash/gap waveforms for timing and freq,
+// shaped by envelope, and the resulting sample sequences for every letter
+// and prosign in table.
+func NewAudioSet(table *CodeTable, timing TimingConfig, freq int, envelope EnvelopeConfig) *AudioSet {
+	dotDuration, dashDuration, elementGap, charGap, wordGap := timing.Durations()
+
+	dot := make([]int16, msToSamples(dotDuration))
+	dash := make([]int16, msToSamples(dashDuration))
+	elementGapAudio := make([]int16, msToSamples(elementGap))
+	charGapAudio := make([]int16, msToSamples(charGap))
+	wordGapAudio := make([]int16, msToSamples(wordGap))
+
+	generateTone(dot, freq)
+	generateTone(dash, freq)
+	envelope.Apply(dot, SampleRate)
+	envelope.Apply(dash, SampleRate)
+
+	renderPattern := func(pattern string) []int16 {
+		var samples []int16
+		for i, element := range pattern {
+			if element == '.' {
+				samples = append(samples, dot...)
+			} else if element == '-' {
+				samples = append(samples, dash...)
+			}
+			if i < len(pattern)-1 {
+				samples = append(samples, elementGapAudio...)
+			}
+		}
+		return samples
+	}
+
+	letterSamples := make(map[rune][]int16, len(table.Letters))
+	for r, pattern := range table.Letters {
+		letterSamples[r] = renderPattern(pattern)
+	}
+
+	prosignSamples := make(map[string][]int16, len(table.Prosigns))
+	for name, pattern := range table.Prosigns {
+		prosignSamples[name] = renderPattern(pattern)
+	}
+
+	return &AudioSet{
+		DotSamples:     dot,
+		DashSamples:    dash,
+		ElementGap:     elementGapAudio,
+		CharGap:        charGapAudio,
+		WordGap:        wordGapAudio,
+		LetterSamples:  letterSamples,
+		ProsignSamples: prosignSamples,
+	}
+}
+
+func msToSamples(ms int) int {
+	return int(float64(ms) * SampleRate / 1000)
+}
+
+func generateTone(samples []int16, freq int) {
+	for i := range samples {
+		samples[i] = int16(math.Sin(2*math.Pi*float64(freq)*float64(i)/float64(SampleRate)) * 32767)
+	}
+}
+
+// samplesFor resolves one Token to its pre-generated sample buffer. It
+// returns an *ErrUnknownToken rather than silently skipping a character
+// the table doesn't cover.
+func (a *AudioSet) samplesFor(tok Token) ([]int16, error) {
+	if tok.IsProsign {
+		samples, ok := a.ProsignSamples[tok.Name]
+		if !ok {
+			return nil, &ErrUnknownToken{Token: "<" + tok.Name + ">"}
+		}
+		return samples, nil
+	}
+	samples, ok := a.LetterSamples[tok.Rune]
+	if !ok {
+		return nil, &ErrUnknownToken{Token: string(tok.Rune)}
+	}
+	return samples, nil
+}
+
+// GenerateSamples tokenizes text (handling "<PROSIGN>" runs and full
+// Unicode) against table and renders it to a single, fully concatenated
+// sample slice, returning it along with its length. It's the simple,
+// whole-message path; MorseReader offers a streaming alternative for long
+// text. An unrecognized character or prosign is reported as an error
+// rather than silently dropped.
+func GenerateSamples(text string, table *CodeTable, timing TimingConfig, freq int, envelope EnvelopeConfig) ([]int16, int, error) {
+	audioSet := NewAudioSet(table, timing, freq, envelope)
+	tokens := Tokenize(text)
+
+	elements := make([][]int16, 0, len(tokens))
+	prevWasLetter := false
+	for _, tok := range tokens {
+		if tok.IsSpace {
+			elements = append(elements, audioSet.WordGap)
+			prevWasLetter = false
+			continue
+		}
+
+		samples, err := audioSet.samplesFor(tok)
+		if err != nil {
+			return nil, 0, err
+		}
+		if prevWasLetter {
+			elements = append(elements, audioSet.CharGap)
+		}
+		elements = append(elements, samples)
+		prevWasLetter = true
+	}
+
+	totalSamples := 0
+	for _, e := range elements {
+		totalSamples += len(e)
+	}
+
+	out := make([]int16, totalSamples)
+	pos := 0
+	for _, e := range elements {
+		pos += copy(out[pos:], e)
+	}
+
+	return out, totalSamples, nil
+}
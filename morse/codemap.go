@@ -0,0 +1,61 @@
+// Package morse holds the core Morse code tables and timing helpers shared
+// by the CLI, the keyer, and the decoder.
+package morse
+
+// CodeMap is the canonical rune-to-Morse-pattern table for the ITU
+// international alphabet, numerals, and punctuation.
+var CodeMap = map[rune]string{
+	'A': ".-", 'B': "-...", 'C': "-.-.", 'D': "-..", 'E': ".", 'F': "..-.",
+	'G': "--.", 'H': "....", 'I': "..", 'J': ".---", 'K': "-.-", 'L': ".-..",
+	'M': "--", 'N': "-.", 'O': "---", 'P': ".--.", 'Q': "--.-", 'R': ".-.",
+	'S': "...", 'T': "-", 'U': "..-", 'V': "...-", 'W': ".--", 'X': "-..-",
+	'Y': "-.--", 'Z': "--..",
+	'0': "-----", '1': ".----", '2': "..---", '3': "...--", '4': "....-",
+	'5': ".....", '6': "-....", '7': "--...", '8': "---..", '9': "----.",
+	'/': "-..-.", '?': "..--..", '.': ".-.-.-", ',': "--..--",
+	'!': "-.-.--", ':': "---...", ';': "-.-.-.", '=': "-...-",
+	'+': ".-.-.", '-': "-....-", '_': "..--.-", '"': ".-..-.",
+	'$': "...-..-", '@': ".--.-.", '&': ".-...",
+	'(': "-.--.", ')': "-.--.-", '\'': ".----.",
+}
+
+// ReverseCodeMap maps a Morse pattern back to its rune, built once from
+// CodeMap so the decoder doesn't have to scan it linearly.
+var ReverseCodeMap = reverseCodeMap()
+
+func reverseCodeMap() map[string]rune {
+	reverse := make(map[string]rune, len(CodeMap))
+	for r, pattern := range CodeMap {
+		reverse[pattern] = r
+	}
+	return reverse
+}
+
+// KochOrder is the traditional Koch-method character introduction order:
+// each new character is added only once the learner has mastered the ones
+// before it.
+var KochOrder = []rune{
+	'K', 'M', 'R', 'S', 'U', 'A', 'P', 'T', 'L', 'O',
+	'W', 'I', '.', 'N', 'J', 'E', 'F', '0', 'Y', 'V',
+	',', 'G', '5', 'Q', '9', 'Z', 'H', '3', '8', 'B',
+	'?', '4', '2', '7', 'C', '1', 'D', '6', 'X', '/',
+}
+
+// KochCodeMap returns the subset of CodeMap unlocked at the given Koch
+// lesson level: level 1 is just KochOrder[0], level 2 adds KochOrder[1],
+// and so on. A level at or beyond len(KochOrder) returns every character
+// in KochOrder; CodeMap entries outside KochOrder are never included.
+func KochCodeMap(level int) map[rune]string {
+	if level <= 0 {
+		level = 1
+	}
+	if level > len(KochOrder) {
+		level = len(KochOrder)
+	}
+
+	enabled := make(map[rune]string, level)
+	for _, r := range KochOrder[:level] {
+		enabled[r] = CodeMap[r]
+	}
+	return enabled
+}
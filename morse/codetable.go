@@ -0,0 +1,109 @@
+package morse
+
+import "fmt"
+
+// Prosigns are the common procedural signals, keyed by their conventional
+// name (without the angle brackets used to write them inline, e.g.
+// "<SOS>"). They're sent as a single run with no inter-letter gap, which is
+// why they need their own pattern rather than being spelled out letter by
+// letter.
+var Prosigns = map[string]string{
+	"SOS": "...---...",
+	"AR":  ".-.-.",
+	"SK":  "...-.-",
+	"BT":  "-...-",
+	"KN":  "-.--.",
+}
+
+// CodeTable is a named, pluggable Morse alphabet: a letter/digit table plus
+// the prosigns available alongside it. Callers select one by name (the
+// ITU international table is the default) via the -table flag.
+type CodeTable struct {
+	Name     string
+	Letters  map[rune]string
+	Prosigns map[string]string
+}
+
+// tables is the registry of code tables selectable by name.
+var tables = map[string]*CodeTable{}
+
+// RegisterTable adds t to the registry under t.Name, overwriting any
+// existing table of the same name.
+func RegisterTable(t *CodeTable) {
+	tables[t.Name] = t
+}
+
+// Table looks up a registered CodeTable by name.
+func Table(name string) (*CodeTable, bool) {
+	t, ok := tables[name]
+	return t, ok
+}
+
+// DefaultTable returns the ITU international table, the one -table selects
+// by default.
+func DefaultTable() *CodeTable {
+	t, _ := Table("itu")
+	return t
+}
+
+func init() {
+	RegisterTable(&CodeTable{Name: "itu", Letters: CodeMap, Prosigns: Prosigns})
+	RegisterTable(&CodeTable{Name: "cyrillic", Letters: CyrillicMap, Prosigns: Prosigns})
+	RegisterTable(&CodeTable{Name: "greek", Letters: GreekMap, Prosigns: Prosigns})
+	RegisterTable(&CodeTable{Name: "wabun", Letters: WabunMap, Prosigns: Prosigns})
+}
+
+// Lookup resolves a single rune against the table's letters.
+func (t *CodeTable) Lookup(r rune) (string, bool) {
+	pattern, ok := t.Letters[r]
+	return pattern, ok
+}
+
+// LookupProsign resolves a prosign name (without angle brackets, already
+// upper-cased) against the table's prosigns.
+func (t *CodeTable) LookupProsign(name string) (string, bool) {
+	pattern, ok := t.Prosigns[name]
+	return pattern, ok
+}
+
+// ErrUnknownToken reports a character or prosign that has no entry in the
+// selected CodeTable.
+type ErrUnknownToken struct {
+	Token string
+}
+
+func (e *ErrUnknownToken) Error() string {
+	return fmt.Sprintf("morse: no code for %q in selected table", e.Token)
+}
+
+// CyrillicMap is the Russian Morse alphabet (ITU Cyrillic extension).
+var CyrillicMap = map[rune]string{
+	'А': ".-", 'Б': "-...", 'В': ".--", 'Г': "--.", 'Д': "-..",
+	'Е': ".", 'Ж': "...-", 'З': "--..", 'И': "..", 'Й': ".---",
+	'К': "-.-", 'Л': ".-..", 'М': "--", 'Н': "-.", 'О': "---",
+	'П': ".--.", 'Р': ".-.", 'С': "...", 'Т': "-", 'У': "..-",
+	'Ф': "..-.", 'Х': "....", 'Ц': "-.-.", 'Ч': "---.", 'Ш': "----",
+	'Щ': "--.-", 'Ъ': "--.--", 'Ы': "-.--", 'Ь': "-..-", 'Э': "..-..",
+	'Ю': "..--", 'Я': ".-.-",
+}
+
+// GreekMap is the Greek Morse alphabet.
+var GreekMap = map[rune]string{
+	'Α': ".-", 'Β': "-...", 'Γ': "--.", 'Δ': "-..", 'Ε': ".",
+	'Ζ': "--..", 'Η': "....", 'Θ': "-.-.", 'Ι': "..", 'Κ': "-.-",
+	'Λ': ".-..", 'Μ': "--", 'Ν': "-.", 'Ξ': "-..-", 'Ο': "---",
+	'Π': ".--.", 'Ρ': ".-.", 'Σ': "...", 'Τ': "-", 'Υ': "-.--",
+	'Φ': "..-.", 'Χ': "----", 'Ψ': "--.-", 'Ω': ".--",
+}
+
+// WabunMap is the Japanese Wabun code used to send kana over Morse.
+// Entries are keyed by the katakana rune rather than a romanized letter.
+var WabunMap = map[rune]string{
+	'イ': ".-", 'ロ': ".-.-", 'ハ': "-...", 'ニ': "-.-.", 'ホ': "-..",
+	'ヘ': ".", 'ト': "..-..", 'チ': "..-.", 'リ': "--.", 'ヌ': "....",
+	'ル': "-.--.", 'ヲ': ".---", 'ワ': "-.-", 'カ': ".-..", 'ヨ': "--",
+	'タ': "-.", 'レ': "---", 'ソ': "---.", 'ツ': ".--.", 'ネ': "--.-",
+	'ナ': ".-.", 'ラ': "...", 'ム': "-", 'ウ': "..-", 'ヰ': ".-..-",
+	'ノ': "..--", 'オ': ".-...", 'ク': "...-", 'ヤ': ".--", 'マ': "-..-",
+	'ケ': "-.--", 'フ': "--..",
+}
@@ -0,0 +1,80 @@
+package morse
+
+import "math"
+
+// EnvelopeShape selects the ramp shape applied to the leading and trailing
+// edges of a tone element.
+type EnvelopeShape int
+
+const (
+	// Rectangular leaves the edges hard-gated (the original behavior).
+	Rectangular EnvelopeShape = iota
+	// RaisedCosine ramps amplitude with a half-cosine, the standard shape
+	// for clean CW keying.
+	RaisedCosine
+	// Gaussian ramps amplitude with the rising half of a Gaussian window;
+	// unlike RaisedCosine it starts at about 4% of full amplitude rather
+	// than at zero.
+	Gaussian
+)
+
+// EnvelopeConfig describes the rise/fall shaping applied to each dot and
+// dash so keying doesn't produce audible clicks and sideband splatter.
+type EnvelopeConfig struct {
+	RiseTimeMs float64
+	FallTimeMs float64
+	Shape      EnvelopeShape
+}
+
+// DefaultEnvelope is a 5 ms raised-cosine ramp on both edges, a reasonable
+// default for clean-sounding CW.
+func DefaultEnvelope() EnvelopeConfig {
+	return EnvelopeConfig{RiseTimeMs: 5, FallTimeMs: 5, Shape: RaisedCosine}
+}
+
+// Apply shapes the leading and trailing edges of samples in place. Rise and
+// fall lengths are clipped to half the buffer so very short elements (high
+// WPM) are still fully covered by their own ramps.
+func (e EnvelopeConfig) Apply(samples []int16, sampleRate int) {
+	if e.Shape == Rectangular || len(samples) == 0 {
+		return
+	}
+
+	riseSamples := clipRamp(int(e.RiseTimeMs*float64(sampleRate)/1000), len(samples))
+	fallSamples := clipRamp(int(e.FallTimeMs*float64(sampleRate)/1000), len(samples))
+
+	for i := 0; i < riseSamples; i++ {
+		gain := e.gain(float64(i) / float64(riseSamples))
+		samples[i] = int16(float64(samples[i]) * gain)
+	}
+	for i := 0; i < fallSamples; i++ {
+		idx := len(samples) - 1 - i
+		gain := e.gain(float64(i) / float64(fallSamples))
+		samples[idx] = int16(float64(samples[idx]) * gain)
+	}
+}
+
+// clipRamp keeps a ramp from covering more than half the element, so a dot
+// shorter than 2*rise (or 2*fall) still has a well-defined ramp on both
+// edges instead of overlapping itself.
+func clipRamp(rampSamples, total int) int {
+	if rampSamples > total/2 {
+		return total / 2
+	}
+	return rampSamples
+}
+
+// gain returns the amplitude multiplier at position t in [0,1) along a
+// ramp, per the configured shape.
+func (e EnvelopeConfig) gain(t float64) float64 {
+	switch e.Shape {
+	case Gaussian:
+		// Centered Gaussian window, sigma chosen so the ramp reaches
+		// ~1.0 by t=1.
+		const sigma = 0.4
+		x := (t - 1) / sigma
+		return math.Exp(-0.5 * x * x)
+	default: // RaisedCosine
+		return 0.5 * (1 - math.Cos(math.Pi*t))
+	}
+}
@@ -0,0 +1,38 @@
+package morse
+
+import "testing"
+
+func TestApplyRectangularLeavesSamplesUnchanged(t *testing.T) {
+	samples := []int16{100, 200, 300, 400}
+	want := []int16{100, 200, 300, 400}
+	EnvelopeConfig{Shape: Rectangular, RiseTimeMs: 5, FallTimeMs: 5}.Apply(samples, SampleRate)
+	for i := range samples {
+		if samples[i] != want[i] {
+			t.Errorf("samples[%d] = %d, want %d", i, samples[i], want[i])
+		}
+	}
+}
+
+func TestApplyRaisedCosineRampsEdgesTowardZero(t *testing.T) {
+	samples := make([]int16, 100)
+	for i := range samples {
+		samples[i] = 1000
+	}
+	EnvelopeConfig{Shape: RaisedCosine, RiseTimeMs: 1, FallTimeMs: 1}.Apply(samples, SampleRate)
+
+	if samples[0] >= 100 {
+		t.Errorf("first sample = %d, want it ramped down near 0", samples[0])
+	}
+	if samples[len(samples)-1] >= 100 {
+		t.Errorf("last sample = %d, want it ramped down near 0", samples[len(samples)-1])
+	}
+}
+
+func TestClipRampNeverExceedsHalfTheElement(t *testing.T) {
+	if got := clipRamp(50, 10); got != 5 {
+		t.Errorf("clipRamp(50, 10) = %d, want 5", got)
+	}
+	if got := clipRamp(3, 10); got != 3 {
+		t.Errorf("clipRamp(3, 10) = %d, want 3", got)
+	}
+}
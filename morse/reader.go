@@ -0,0 +1,162 @@
+package morse
+
+import (
+	"io"
+	"strings"
+	"unsafe"
+)
+
+// MorseReader streams Morse audio samples for text lazily, one element at
+// a time, reusing the AudioSet's pre-generated per-letter and per-prosign
+// buffers instead of concatenating the whole message into memory up
+// front. This keeps memory proportional to the longest element rather
+// than to the message length, so it scales to long text, files, or a live
+// text-to-Morse server.
+type MorseReader struct {
+	tokens        *TokenReader
+	audio         *AudioSet
+	queue         [][]int16 // buffers for the element(s) currently in flight
+	current       []int16   // the buffer being drained right now
+	pos           int       // read position within current, in samples
+	prevWasLetter bool
+	err           error // sticky: an unknown token or upstream read error
+
+	hasCarry  bool // true if carryByte holds a sample's high byte
+	carryByte byte
+}
+
+// NewMorseReader returns a MorseReader that reads runes from src and emits
+// their Morse audio against table, timed and shaped by
+// timing/freq/envelope.
+func NewMorseReader(src io.RuneReader, table *CodeTable, timing TimingConfig, freq int, envelope EnvelopeConfig) *MorseReader {
+	return &MorseReader{
+		tokens: NewTokenReader(src),
+		audio:  NewAudioSet(table, timing, freq, envelope),
+	}
+}
+
+// NewMorseReaderString is a convenience constructor over a strings.Reader.
+func NewMorseReaderString(text string, table *CodeTable, timing TimingConfig, freq int, envelope EnvelopeConfig) *MorseReader {
+	return NewMorseReader(strings.NewReader(text), table, timing, freq, envelope)
+}
+
+// Read implements io.Reader, emitting little-endian 16-bit PCM samples.
+// p may be any length, including odd: a sample split across two calls
+// has its trailing byte held in the reader and emitted first on the next
+// call. An unrecognized character or prosign is reported as an
+// *ErrUnknownToken instead of being silently dropped.
+func (m *MorseReader) Read(p []byte) (int, error) {
+	n := 0
+	if m.hasCarry && n < len(p) {
+		p[n] = m.carryByte
+		m.hasCarry = false
+		n++
+	}
+
+	for n < len(p) {
+		if m.current == nil || m.pos >= len(m.current) {
+			if !m.advance() {
+				if n == 0 {
+					if m.err != nil {
+						return 0, m.err
+					}
+					return 0, io.EOF
+				}
+				return n, nil
+			}
+		}
+
+		sample := m.current[m.pos]
+		lo, hi := byte(sample), byte(sample>>8)
+		if n+1 < len(p) {
+			p[n] = lo
+			p[n+1] = hi
+			n += 2
+			m.pos++
+			continue
+		}
+
+		p[n] = lo
+		m.carryByte = hi
+		m.hasCarry = true
+		m.pos++
+		n++
+	}
+	return n, nil
+}
+
+// advance pulls the next token from the source and queues the element
+// buffer(s) (tone plus any gap) it maps to. It returns false once the
+// source and queue are both exhausted, or once a token fails to resolve
+// (m.err is set in that case).
+func (m *MorseReader) advance() bool {
+	for len(m.queue) == 0 {
+		if m.err != nil {
+			return false
+		}
+
+		tok, err := m.tokens.ReadToken()
+		if err != nil {
+			if err != io.EOF {
+				m.err = err
+			}
+			return false
+		}
+
+		if tok.IsSpace {
+			m.queue = append(m.queue, m.audio.WordGap)
+			m.prevWasLetter = false
+			continue
+		}
+
+		samples, serr := m.audio.samplesFor(tok)
+		if serr != nil {
+			m.err = serr
+			return false
+		}
+		if m.prevWasLetter {
+			m.queue = append(m.queue, m.audio.CharGap)
+		}
+		m.queue = append(m.queue, samples)
+		m.prevWasLetter = true
+	}
+
+	m.current, m.queue = m.queue[0], m.queue[1:]
+	m.pos = 0
+	return true
+}
+
+// WriteTo implements io.WriterTo, streaming samples to w without the
+// per-sample allocation that binary.Write incurs: each queued element
+// buffer is reinterpreted directly as a byte slice via unsafe.Slice and
+// written in one call.
+func (m *MorseReader) WriteTo(w io.Writer) (int64, error) {
+	var total int64
+	for {
+		if m.current == nil || m.pos >= len(m.current) {
+			if !m.advance() {
+				return total, m.err
+			}
+		}
+
+		remaining := m.current[m.pos:]
+		m.pos = len(m.current)
+
+		bytes := int16SamplesToBytes(remaining)
+		n, err := w.Write(bytes)
+		total += int64(n)
+		if err != nil {
+			return total, err
+		}
+	}
+}
+
+// int16SamplesToBytes reinterprets a little-endian platform's []int16 as
+// []byte without copying. It assumes a little-endian target, which covers
+// every platform this CLI ships for.
+func int16SamplesToBytes(samples []int16) []byte {
+	if len(samples) == 0 {
+		return nil
+	}
+	return unsafe.Slice((*byte)(unsafe.Pointer(&samples[0])), len(samples)*2)
+}
@@ -0,0 +1,73 @@
+package morse
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestMorseReaderOddLengthReadsDontHang(t *testing.T) {
+	table, _ := Table("itu")
+	reader := NewMorseReaderString("SOS", table, NewTiming(20), 600, DefaultEnvelope())
+
+	done := make(chan struct{})
+	var all []byte
+	go func() {
+		defer close(done)
+		buf := make([]byte, 3)
+		for {
+			n, err := reader.Read(buf)
+			all = append(all, buf[:n]...)
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				t.Errorf("Read: %v", err)
+				return
+			}
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Read with odd-length buffer hung")
+	}
+
+	if len(all) == 0 {
+		t.Error("got no samples")
+	}
+	if len(all)%2 != 0 {
+		t.Errorf("got %d bytes, want an even count of reassembled samples", len(all))
+	}
+}
+
+func TestMorseReaderMatchesWriteTo(t *testing.T) {
+	table, _ := Table("itu")
+	timing := NewTiming(20)
+	envelope := DefaultEnvelope()
+
+	var viaWriteTo bytes.Buffer
+	if _, err := NewMorseReaderString("SOS", table, timing, 600, envelope).WriteTo(&viaWriteTo); err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+
+	var viaRead bytes.Buffer
+	reader := NewMorseReaderString("SOS", table, timing, 600, envelope)
+	buf := make([]byte, 7) // deliberately odd
+	for {
+		n, err := reader.Read(buf)
+		viaRead.Write(buf[:n])
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Read: %v", err)
+		}
+	}
+
+	if !bytes.Equal(viaWriteTo.Bytes(), viaRead.Bytes()) {
+		t.Errorf("Read and WriteTo produced different samples (%d vs %d bytes)", viaRead.Len(), viaWriteTo.Len())
+	}
+}
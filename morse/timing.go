@@ -0,0 +1,70 @@
+package morse
+
+// TimingConfig describes how WPM translates into element durations. A
+// plain TimingConfig (CharWPM == EffectiveWPM) reproduces the classic PARIS
+// timing; NewFarnsworthTiming produces one where characters are sent at a
+// faster CharWPM but the gaps are stretched to a slower EffectiveWPM, which
+// is how operators practice copying at full character speed while keeping
+// the overall pace approachable.
+type TimingConfig struct {
+	CharWPM      int
+	EffectiveWPM int
+}
+
+// NewTiming builds a plain, non-Farnsworth TimingConfig for wpm.
+func NewTiming(wpm int) TimingConfig {
+	return TimingConfig{CharWPM: wpm, EffectiveWPM: wpm}
+}
+
+// NewFarnsworthTiming builds a TimingConfig where dots/dashes are timed at
+// charWPM but inter-character and word gaps are stretched so the overall
+// sending rate works out to effWPM.
+func NewFarnsworthTiming(charWPM, effWPM int) TimingConfig {
+	return TimingConfig{CharWPM: charWPM, EffectiveWPM: effWPM}
+}
+
+// Durations returns the element durations in milliseconds: dot, dash,
+// inter-element gap, inter-character gap, and inter-word gap.
+//
+// Dot/dash/element-gap come straight from the PARIS standard at CharWPM.
+// When EffectiveWPM is slower than CharWPM, the standard Farnsworth formula
+// computes ta (in seconds), the total spacing time per PARIS word, and
+// splits it into 19 equal gap units: a character gap is 3 of them and a
+// word gap 7 (a character gap plus 4 more):
+//
+//	ta = (60*CharWPM - 37.2*EffectiveWPM) / (CharWPM*EffectiveWPM)
+func (tc TimingConfig) Durations() (dotDuration, dashDuration, elementGap, charGap, wordGap int) {
+	charWPM := tc.CharWPM
+	if charWPM <= 0 {
+		charWPM = 20
+	}
+	effWPM := tc.EffectiveWPM
+	if effWPM <= 0 || effWPM > charWPM {
+		effWPM = charWPM
+	}
+
+	timeUnit := 60000 / (charWPM * 50)
+	dotDuration = timeUnit
+	dashDuration = timeUnit * 3
+	elementGap = timeUnit
+
+	if effWPM == charWPM {
+		charGap = timeUnit * 3
+		wordGap = timeUnit * 7
+		return
+	}
+
+	// Farnsworth total spacing time per PARIS word, in milliseconds.
+	ta := (60*float64(charWPM) - 37.2*float64(effWPM)) / (float64(charWPM) * float64(effWPM)) * 1000
+
+	charGap = int(ta * 3 / 19)
+	wordGap = int(ta * 7 / 19)
+	return
+}
+
+// CalculateTiming calculates timing from WPM using the PARIS standard. It
+// is equivalent to NewTiming(wpm).Durations() and kept for callers that
+// only care about a single WPM speed.
+func CalculateTiming(wpm int) (dotDuration, dashDuration, elementGap, charGap, wordGap int) {
+	return NewTiming(wpm).Durations()
+}
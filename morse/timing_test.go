@@ -0,0 +1,49 @@
+package morse
+
+import "testing"
+
+func TestDurationsPlainMatchesParis(t *testing.T) {
+	dot, dash, elementGap, charGap, wordGap := NewTiming(20).Durations()
+	if dot != 60 {
+		t.Errorf("dot = %d, want 60", dot)
+	}
+	if dash != 3*dot {
+		t.Errorf("dash = %d, want %d", dash, 3*dot)
+	}
+	if elementGap != dot {
+		t.Errorf("elementGap = %d, want %d", elementGap, dot)
+	}
+	if charGap != 3*dot {
+		t.Errorf("charGap = %d, want %d", charGap, 3*dot)
+	}
+	if wordGap != 7*dot {
+		t.Errorf("wordGap = %d, want %d", wordGap, 7*dot)
+	}
+}
+
+func TestDurationsFarnsworthStretchesGapsOnly(t *testing.T) {
+	plainDot, plainDash, plainElementGap, plainCharGap, plainWordGap := NewTiming(20).Durations()
+	dot, dash, elementGap, charGap, wordGap := NewFarnsworthTiming(20, 5).Durations()
+
+	if dot != plainDot || dash != plainDash || elementGap != plainElementGap {
+		t.Errorf("Farnsworth changed element timing: dot=%d dash=%d elementGap=%d", dot, dash, elementGap)
+	}
+	if charGap <= plainCharGap {
+		t.Errorf("charGap = %d, want > %d (plain)", charGap, plainCharGap)
+	}
+	if wordGap <= plainWordGap {
+		t.Errorf("wordGap = %d, want > %d (plain)", wordGap, plainWordGap)
+	}
+}
+
+func TestDurationsFarnsworthIgnoredWhenNotSlower(t *testing.T) {
+	plain := NewTiming(20)
+	farnsworth := NewFarnsworthTiming(20, 25) // effWPM > charWPM: not a valid Farnsworth speed
+
+	pDot, pDash, pGap, pCharGap, pWordGap := plain.Durations()
+	fDot, fDash, fGap, fCharGap, fWordGap := farnsworth.Durations()
+	if pDot != fDot || pDash != fDash || pGap != fGap || pCharGap != fCharGap || pWordGap != fWordGap {
+		t.Errorf("effWPM > charWPM should fall back to plain timing, got %v want %v",
+			[]int{fDot, fDash, fGap, fCharGap, fWordGap}, []int{pDot, pDash, pGap, pCharGap, pWordGap})
+	}
+}
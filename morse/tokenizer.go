@@ -0,0 +1,98 @@
+package morse
+
+import (
+	"io"
+	"strings"
+	"unicode"
+)
+
+// Token is one unit of input text to render: a single letter/digit/
+// punctuation rune, a prosign parsed from a "<...>" run, or a word-gap
+// space.
+type Token struct {
+	IsSpace   bool
+	IsProsign bool
+	Rune      rune   // valid when !IsSpace && !IsProsign
+	Name      string // prosign name, upper-cased, without the brackets
+}
+
+// TokenReader turns a stream of runes into Tokens, collapsing any
+// "<...>" run into a single prosign Token rather than treating '<', '.',
+// '.', '.', '>' as five separate characters. If a "<" is never closed
+// before the source ends, the '<' and everything consumed looking for
+// '>' are queued back as literal tokens instead of being discarded.
+type TokenReader struct {
+	rr      io.RuneReader
+	pending []Token
+}
+
+// NewTokenReader wraps rr as a TokenReader.
+func NewTokenReader(rr io.RuneReader) *TokenReader {
+	return &TokenReader{rr: rr}
+}
+
+// ReadToken returns the next Token, or an error (io.EOF once rr is
+// exhausted and nothing is queued) if none remains.
+func (t *TokenReader) ReadToken() (Token, error) {
+	if len(t.pending) > 0 {
+		tok := t.pending[0]
+		t.pending = t.pending[1:]
+		return tok, nil
+	}
+
+	r, _, err := t.rr.ReadRune()
+	if err != nil {
+		return Token{}, err
+	}
+
+	if r == ' ' {
+		return Token{IsSpace: true}, nil
+	}
+	if r != '<' {
+		return Token{Rune: unicode.ToUpper(r)}, nil
+	}
+
+	var consumed []rune
+	for {
+		next, _, err := t.rr.ReadRune()
+		if err != nil {
+			// Unterminated prosign: queue what we consumed as literal
+			// tokens rather than silently dropping it, and return the
+			// leading '<' as its own literal token now.
+			t.pending = append(t.pending, literalTokens(consumed)...)
+			return Token{Rune: '<'}, nil
+		}
+		if next == '>' {
+			return Token{IsProsign: true, Name: strings.ToUpper(string(consumed))}, nil
+		}
+		consumed = append(consumed, next)
+	}
+}
+
+// literalTokens converts runes that failed to form a prosign back into
+// plain Tokens.
+func literalTokens(runes []rune) []Token {
+	tokens := make([]Token, 0, len(runes))
+	for _, r := range runes {
+		if r == ' ' {
+			tokens = append(tokens, Token{IsSpace: true})
+			continue
+		}
+		tokens = append(tokens, Token{Rune: unicode.ToUpper(r)})
+	}
+	return tokens
+}
+
+// Tokenize splits text into Tokens in one pass; see TokenReader for the
+// streaming equivalent.
+func Tokenize(text string) []Token {
+	tr := NewTokenReader(strings.NewReader(text))
+	var tokens []Token
+	for {
+		tok, err := tr.ReadToken()
+		if err != nil {
+			return tokens
+		}
+		tokens = append(tokens, tok)
+	}
+}
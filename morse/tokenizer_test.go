@@ -0,0 +1,49 @@
+package morse
+
+import "testing"
+
+func TestTokenizeUppercasesUnicodeLetters(t *testing.T) {
+	tokens := Tokenize("привет")
+	for i, tok := range tokens {
+		if tok.Rune != []rune("ПРИВЕТ")[i] {
+			t.Errorf("token %d = %q, want %q", i, tok.Rune, []rune("ПРИВЕТ")[i])
+		}
+	}
+}
+
+func TestTokenizeCollapsesProsign(t *testing.T) {
+	tokens := Tokenize("<SOS>")
+	if len(tokens) != 1 {
+		t.Fatalf("got %d tokens, want 1: %+v", len(tokens), tokens)
+	}
+	if !tokens[0].IsProsign || tokens[0].Name != "SOS" {
+		t.Errorf("token = %+v, want prosign SOS", tokens[0])
+	}
+}
+
+func TestTokenizeUnterminatedProsignKeepsRunes(t *testing.T) {
+	tokens := Tokenize("<AB")
+	var got []rune
+	for _, tok := range tokens {
+		if tok.IsProsign {
+			t.Fatalf("unexpected prosign token: %+v", tok)
+		}
+		got = append(got, tok.Rune)
+	}
+	want := []rune{'<', 'A', 'B'}
+	if len(got) != len(want) {
+		t.Fatalf("got runes %q, want %q", string(got), string(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("rune %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestTokenizeSpaceIsWordGap(t *testing.T) {
+	tokens := Tokenize("A B")
+	if len(tokens) != 3 || !tokens[1].IsSpace {
+		t.Fatalf("tokens = %+v, want [A, space, B]", tokens)
+	}
+}
@@ -0,0 +1,68 @@
+//go:build ebiten
+
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2/audio"
+	"github.com/hajimehoshi/ebiten/v2/audio/wav"
+)
+
+// playbackPollInterval is how often Close polls IsPlaying while waiting
+// for playback to finish, instead of busy-looping on a core.
+const playbackPollInterval = 10 * time.Millisecond
+
+// EbitenSink plays samples back live through an Ebiten audio context,
+// buffering everything until Close starts playback (Ebiten's player needs
+// a complete, seekable source rather than an incremental one).
+type EbitenSink struct {
+	ctx        *audio.Context
+	sampleRate int
+	buf        bytes.Buffer
+}
+
+// NewEbitenSink creates a live playback Sink on ctx.
+func NewEbitenSink(ctx *audio.Context, sampleRate int) *EbitenSink {
+	return &EbitenSink{ctx: ctx, sampleRate: sampleRate}
+}
+
+// WriteSamples buffers samples for playback on Close.
+func (s *EbitenSink) WriteSamples(samples []int16) error {
+	return NewPCMSink(&s.buf).WriteSamples(samples)
+}
+
+// Write implements io.Writer over already-encoded little-endian 16-bit PCM
+// bytes, letting callers (e.g. MorseReader.WriteTo) skip the int16
+// round-trip WriteSamples requires.
+func (s *EbitenSink) Write(p []byte) (int, error) {
+	return s.buf.Write(p)
+}
+
+// Close converts the buffered PCM to a WAV stream Ebiten can decode, then
+// plays it and blocks until playback finishes.
+func (s *EbitenSink) Close() error {
+	var wavBuf bytes.Buffer
+	if err := writeWavHeader(&wavBuf, s.buf.Len(), s.sampleRate); err != nil {
+		return err
+	}
+	wavBuf.Write(s.buf.Bytes())
+
+	decoded, err := wav.DecodeWithSampleRate(s.sampleRate, io.Reader(bytes.NewReader(wavBuf.Bytes())))
+	if err != nil {
+		return fmt.Errorf("ebiten sink: decode: %w", err)
+	}
+
+	player, err := s.ctx.NewPlayer(decoded)
+	if err != nil {
+		return fmt.Errorf("ebiten sink: new player: %w", err)
+	}
+	player.Play()
+	for player.IsPlaying() {
+		time.Sleep(playbackPollInterval)
+	}
+	return nil
+}
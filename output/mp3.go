@@ -0,0 +1,47 @@
+//go:build mp3
+
+package output
+
+import (
+	"io"
+
+	"github.com/viert/lame"
+)
+
+// MP3Sink LAME-encodes PCM samples to MP3 as they arrive.
+type MP3Sink struct {
+	enc *lame.LameWriter
+}
+
+// NewMP3Sink wraps w as an MP3 Sink at the given sample rate, encoding at
+// LAME's default quality/bitrate settings for mono 16-bit input.
+func NewMP3Sink(w io.Writer, sampleRate int) *MP3Sink {
+	enc := lame.NewWriter(w)
+	enc.Encoder.SetInSamplerate(sampleRate)
+	enc.Encoder.SetNumChannels(1)
+	enc.Encoder.InitParams()
+	return &MP3Sink{enc: enc}
+}
+
+// WriteSamples encodes samples as they arrive.
+func (s *MP3Sink) WriteSamples(samples []int16) error {
+	buf := make([]byte, len(samples)*2)
+	for i, sample := range samples {
+		buf[i*2] = byte(sample)
+		buf[i*2+1] = byte(sample >> 8)
+	}
+	_, err := s.enc.Write(buf)
+	return err
+}
+
+// Write implements io.Writer over already-encoded little-endian 16-bit PCM
+// bytes, letting callers (e.g. MorseReader.WriteTo) skip the int16
+// round-trip WriteSamples requires.
+func (s *MP3Sink) Write(p []byte) (int, error) {
+	return s.enc.Write(p)
+}
+
+// Close flushes the LAME encoder's trailing frames.
+func (s *MP3Sink) Close() error {
+	return s.enc.Close()
+}
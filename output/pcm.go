@@ -0,0 +1,39 @@
+package output
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// PCMSink writes raw little-endian 16-bit PCM samples to w, with no
+// container or header: just the samples, back to back.
+type PCMSink struct {
+	w io.Writer
+}
+
+// NewPCMSink wraps w as a raw PCM Sink.
+func NewPCMSink(w io.Writer) *PCMSink {
+	return &PCMSink{w: w}
+}
+
+// WriteSamples appends samples to the underlying writer.
+func (s *PCMSink) WriteSamples(samples []int16) error {
+	for _, sample := range samples {
+		if err := binary.Write(s.w, binary.LittleEndian, sample); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Write implements io.Writer over already-encoded little-endian 16-bit PCM
+// bytes, letting callers (e.g. MorseReader.WriteTo) skip the int16
+// round-trip WriteSamples requires.
+func (s *PCMSink) Write(p []byte) (int, error) {
+	return s.w.Write(p)
+}
+
+// Close is a no-op; PCMSink has no trailing header to flush.
+func (s *PCMSink) Close() error {
+	return nil
+}
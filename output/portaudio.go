@@ -0,0 +1,87 @@
+//go:build portaudio
+
+package output
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/gordonklaus/portaudio"
+)
+
+// PortAudioSink streams samples to the default output device as they
+// arrive, with no intermediate file container.
+type PortAudioSink struct {
+	stream *portaudio.Stream
+	queue  chan float32
+
+	queued int64 // samples WriteSamples has ever enqueued
+	played int64 // samples fillBuffer has ever drawn from the queue
+}
+
+// NewPortAudioSink opens a PortAudio output stream at sampleRate and
+// starts it immediately.
+func NewPortAudioSink(sampleRate int) (*PortAudioSink, error) {
+	if err := portaudio.Initialize(); err != nil {
+		return nil, fmt.Errorf("portaudio sink: initialize: %w", err)
+	}
+
+	s := &PortAudioSink{queue: make(chan float32, sampleRate)}
+	stream, err := portaudio.OpenDefaultStream(0, 1, float64(sampleRate), 0, s.fillBuffer)
+	if err != nil {
+		portaudio.Terminate()
+		return nil, fmt.Errorf("portaudio sink: open stream: %w", err)
+	}
+	if err := stream.Start(); err != nil {
+		stream.Close()
+		portaudio.Terminate()
+		return nil, fmt.Errorf("portaudio sink: start stream: %w", err)
+	}
+
+	s.stream = stream
+	return s, nil
+}
+
+// fillBuffer drains queued samples into the audio callback, padding with
+// silence if the queue runs dry.
+func (s *PortAudioSink) fillBuffer(out []float32) {
+	for i := range out {
+		select {
+		case sample := <-s.queue:
+			out[i] = sample
+			atomic.AddInt64(&s.played, 1)
+		default:
+			out[i] = 0
+		}
+	}
+}
+
+// WriteSamples enqueues samples for playback, converting from int16 to the
+// float32 range PortAudio expects.
+func (s *PortAudioSink) WriteSamples(samples []int16) error {
+	atomic.AddInt64(&s.queued, int64(len(samples)))
+	for _, sample := range samples {
+		s.queue <- float32(sample) / 32768
+	}
+	return nil
+}
+
+// Close waits for every queued sample to actually reach fillBuffer, then
+// stops and releases the PortAudio stream. Closing the stream right after
+// WriteSamples returns would tear it down before playback happens: queuing
+// a short message completes well before the callback has drained it.
+// stream.Stop, unlike stream.Close, blocks until all pending buffers have
+// played.
+func (s *PortAudioSink) Close() error {
+	for atomic.LoadInt64(&s.played) < atomic.LoadInt64(&s.queued) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	err := s.stream.Stop()
+	if cerr := s.stream.Close(); err == nil {
+		err = cerr
+	}
+	portaudio.Terminate()
+	return err
+}
@@ -0,0 +1,19 @@
+// Package output decouples Morse sample generation from where the samples
+// end up: a file encoder, a raw pipe, or a live playback device.
+package output
+
+// Sink accepts a stream of 16-bit PCM samples and disposes of them,
+// whether that means encoding them to a file or playing them back live.
+// Callers write samples incrementally and must call Close when done so
+// sinks that buffer (e.g. file encoders needing a final header) can flush.
+//
+// A Sink that also implements io.Writer accepts already-encoded
+// little-endian 16-bit PCM bytes directly, which callers with bytes on
+// hand (e.g. MorseReader.WriteTo) should prefer over WriteSamples to skip
+// its int16 round-trip. WAVSink, PCMSink, MP3Sink and EbitenSink all do;
+// PortAudioSink can't, since it converts samples to float32 rather than
+// passing PCM bytes through.
+type Sink interface {
+	WriteSamples(samples []int16) error
+	Close() error
+}
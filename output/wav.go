@@ -0,0 +1,133 @@
+package output
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+)
+
+// WAVSink writes a RIFF/WAVE stream for PCM samples. When w is also an
+// io.WriteSeeker that can actually seek (a real file, as opposed to a pipe
+// or terminal, even when reached through os.Stdout), it writes a
+// placeholder header immediately and patches the size fields in place on
+// Close, so samples stream straight through instead of being held in
+// memory. Otherwise (w can't be seeked back into) it falls back to
+// buffering everything and writing the header once the final size is
+// known.
+type WAVSink struct {
+	w          io.Writer
+	seeker     io.WriteSeeker // non-nil: w supports patching the header in place
+	sampleRate int
+	dataSize   int
+	buf        bytes.Buffer // used only when seeker is nil
+}
+
+// NewWAVSink wraps w as a WAV Sink at the given sample rate. If w is
+// seekable, a placeholder header is written immediately so samples can
+// follow it directly; Close comes back and fills in the real size.
+func NewWAVSink(w io.Writer, sampleRate int) (*WAVSink, error) {
+	s := &WAVSink{w: w, sampleRate: sampleRate}
+	// *os.File satisfies io.WriteSeeker even for a pipe, so probe that
+	// seeking really works before relying on it.
+	if seeker, ok := w.(io.WriteSeeker); ok {
+		if _, err := seeker.Seek(0, io.SeekCurrent); err == nil {
+			if err := writeWavHeader(w, 0, sampleRate); err != nil {
+				return nil, err
+			}
+			s.seeker = seeker
+		}
+	}
+	return s, nil
+}
+
+// WriteSamples streams samples straight to w if it's seekable, or buffers
+// them for the header written on Close otherwise.
+func (s *WAVSink) WriteSamples(samples []int16) error {
+	if s.seeker == nil {
+		for _, sample := range samples {
+			if err := binary.Write(&s.buf, binary.LittleEndian, sample); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for _, sample := range samples {
+		if err := binary.Write(s.w, binary.LittleEndian, sample); err != nil {
+			return err
+		}
+	}
+	s.dataSize += len(samples) * 2
+	return nil
+}
+
+// Write implements io.Writer over already-encoded little-endian 16-bit PCM
+// bytes, letting callers (e.g. MorseReader.WriteTo) skip the int16
+// round-trip WriteSamples requires.
+func (s *WAVSink) Write(p []byte) (int, error) {
+	if s.seeker == nil {
+		return s.buf.Write(p)
+	}
+	n, err := s.w.Write(p)
+	s.dataSize += n
+	return n, err
+}
+
+// Close writes the RIFF/WAVE header. For a seekable w it was already
+// streaming samples after a placeholder header, so Close just seeks back
+// and patches the size fields in place; otherwise it writes the header
+// followed by the buffered PCM data for the first time.
+func (s *WAVSink) Close() error {
+	if s.seeker != nil {
+		if _, err := s.seeker.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		return writeWavHeader(s.seeker, s.dataSize, s.sampleRate)
+	}
+
+	if err := writeWavHeader(s.w, s.buf.Len(), s.sampleRate); err != nil {
+		return err
+	}
+	_, err := s.w.Write(s.buf.Bytes())
+	return err
+}
+
+// writeWavHeader writes a 44-byte canonical PCM WAV header for mono
+// 16-bit audio at sampleRate, describing dataSize bytes of samples that
+// follow.
+func writeWavHeader(w io.Writer, dataSize int, sampleRate int) error {
+	write := func(v interface{}) error {
+		return binary.Write(w, binary.LittleEndian, v)
+	}
+
+	if _, err := w.Write([]byte("RIFF")); err != nil {
+		return err
+	}
+	if err := write(uint32(36 + dataSize)); err != nil {
+		return err
+	}
+	if _, err := w.Write([]byte("WAVE")); err != nil {
+		return err
+	}
+
+	if _, err := w.Write([]byte("fmt ")); err != nil {
+		return err
+	}
+	for _, v := range []interface{}{
+		uint32(16), // fmt chunk size
+		uint16(1),  // audio format (1 for PCM)
+		uint16(1),  // number of channels
+		uint32(sampleRate),
+		uint32(sampleRate * 2), // byte rate
+		uint16(2),              // block align
+		uint16(16),             // bits per sample
+	} {
+		if err := write(v); err != nil {
+			return err
+		}
+	}
+
+	if _, err := w.Write([]byte("data")); err != nil {
+		return err
+	}
+	return write(uint32(dataSize))
+}
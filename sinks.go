@@ -0,0 +1,19 @@
+package main
+
+import (
+	"io"
+
+	"github.com/pavelanni/morse-go/output"
+)
+
+// These factories are populated by init() in the build-tag-gated
+// main_*.go files (main_ebiten.go, main_portaudio.go, main_mp3.go). A nil
+// factory means the binary was built without the matching tag, and
+// newSink reports that as a runtime error instead of failing the default
+// build for everyone who doesn't have ALSA/PortAudio/LAME headers
+// installed.
+var (
+	ebitenSinkFactory    func(sampleRate int) output.Sink
+	portaudioSinkFactory func(sampleRate int) (output.Sink, error)
+	mp3SinkFactory       func(w io.Writer, sampleRate int) output.Sink
+)